@@ -55,6 +55,25 @@ type Consistent struct {
 	// the hash ring
 	sortedHostsHashSet []uint64
 
+	// the lookup implementation backing GetKey/GetKeyLeast; defaults to backendRing
+	backend backend
+
+	// the size of lookupTable, only used when backend is backendMaglev
+	maglevTableSize int
+
+	// the Maglev lookup table: slot -> host name, only used when backend is backendMaglev
+	lookupTable []string
+
+	// the policy used by GetKeyFrom/GetKeyLeastFrom; defaults to LocalityNone
+	localityPolicy LocalityPolicy
+
+	// the strategy GetKey/GetKeyLeast delegate to when set; nil keeps the
+	// default ring/bounded-load behavior
+	strategy SelectionStrategy
+
+	// the ring change event bus backing Watch
+	events eventBus
+
 	// the hash ring lock
 	sync.RWMutex
 }
@@ -79,6 +98,12 @@ func NewConsistent(replicaNum int, hashFunc func(key string) uint64) *Consistent
 }
 
 func (c *Consistent) RegisterHost(hostName string) error {
+	return c.registerHost(hostName, HostMeta{}, 1)
+}
+
+// registerHost is the shared implementation behind RegisterHost,
+// RegisterHostWithMeta and RegisterHostWithWeight.
+func (c *Consistent) registerHost(hostName string, meta HostMeta, weight float64) error {
 	c.Lock()
 	defer c.Unlock()
 
@@ -86,15 +111,32 @@ func (c *Consistent) RegisterHost(hostName string) error {
 		return ErrHostAlreadyExists
 	}
 
+	if weight <= 0 {
+		weight = 1
+	}
+
+	watched := c.events.hasSubscribers()
+	var prevRing []uint64
+	var prevHostOf map[uint64]string
+	if watched {
+		prevRing, prevHostOf = c.snapshotRing()
+	}
+
 	c.hostMap[hostName] = &Host{
 		Name:      hostName,
 		LoadBound: 0,
+		DC:        meta.DC,
+		Rack:      meta.Rack,
+		Weight:    weight,
 	}
 
-	for i := 0; i < c.replicaNum; i++ {
+	replicas := hostReplicaCount(weight, c.replicaNum)
+	addedHashes := make([]uint64, 0, replicas)
+	for i := 0; i < replicas; i++ {
 		hashedIdx := c.hashFunc(fmt.Sprintf(hostReplicaFormat, hostName, i))
 		c.replicaHostMap[hashedIdx] = hostName
 		c.sortedHostsHashSet = append(c.sortedHostsHashSet, hashedIdx)
+		addedHashes = append(addedHashes, hashedIdx)
 	}
 
 	// sort hashes in ascending order
@@ -105,6 +147,15 @@ func (c *Consistent) RegisterHost(hostName string) error {
 		return false
 	})
 
+	if c.backend == backendMaglev {
+		c.rebuildMaglevLookup()
+	}
+
+	if watched {
+		c.events.publish(RingEvent{Kind: HostAdded, Host: hostName})
+		c.emitHostAddedKeyspaceEvents(hostName, addedHashes, prevRing, prevHostOf)
+	}
+
 	return nil
 }
 
@@ -112,16 +163,35 @@ func (c *Consistent) UnregisterHost(hostName string) error {
 	c.Lock()
 	defer c.Unlock()
 
-	if _, ok := c.hostMap[hostName]; !ok {
+	host, ok := c.hostMap[hostName]
+	if !ok {
 		return ErrHostNotFound
 	}
 
 	delete(c.hostMap, hostName)
 
-	for i := 0; i < c.replicaNum; i++ {
+	watched := c.events.hasSubscribers()
+	var prevRing []uint64
+	if watched {
+		prevRing, _ = c.snapshotRing()
+	}
+
+	replicas := hostReplicaCount(host.Weight, c.replicaNum)
+	removedHashes := make([]uint64, 0, replicas)
+	for i := 0; i < replicas; i++ {
 		hashedIdx := c.hashFunc(fmt.Sprintf(hostReplicaFormat, hostName, i))
 		delete(c.replicaHostMap, hashedIdx)
 		c.delHashIndex(hashedIdx)
+		removedHashes = append(removedHashes, hashedIdx)
+	}
+
+	if c.backend == backendMaglev {
+		c.rebuildMaglevLookup()
+	}
+
+	if watched {
+		c.events.publish(RingEvent{Kind: HostRemoved, Host: hostName})
+		c.emitHostRemovedKeyspaceEvents(hostName, removedHashes, prevRing)
 	}
 
 	return nil
@@ -152,6 +222,16 @@ func (c *Consistent) Hosts() []string {
 }
 
 func (c *Consistent) GetKey(key string) (string, error) {
+	if c.strategy != nil {
+		return c.GetKeyWithStrategy(key, c.strategy)
+	}
+
+	if c.backend == backendMaglev {
+		c.RLock()
+		defer c.RUnlock()
+		return c.getKeyMaglev(key)
+	}
+
 	hashedKey := c.hashFunc(key)
 	idx := c.searchKey(hashedKey)
 	return c.replicaHostMap[c.sortedHostsHashSet[idx]], nil
@@ -166,10 +246,24 @@ func (c *Consistent) GetKeyLeast(key string) (string, error) {
 	c.RLock()
 	defer c.RUnlock()
 
+	return c.getKeyLeastLocked(key)
+}
+
+// getKeyLeastLocked is GetKeyLeast's implementation; callers must hold at
+// least c's read lock.
+func (c *Consistent) getKeyLeastLocked(key string) (string, error) {
 	if len(c.replicaHostMap) == 0 {
 		return "", ErrHostNotFound
 	}
 
+	if c.strategy != nil {
+		return c.strategy.Select(c, c.hashFunc(key))
+	}
+
+	if c.backend == backendMaglev {
+		return c.getKeyLeastMaglev(key)
+	}
+
 	hashedKey := c.hashFunc(key)
 	idx := c.searchKey(hashedKey) // Find the first host that may serve the key
 
@@ -282,7 +376,8 @@ func (c *Consistent) checkLoadCapacity(host string) (bool, error) {
 		return false, ErrHostNotFound
 	}
 
-	if float64(candidateHost.LoadBound)+1 <= avgLoadPerNode {
+	// scale the cap by the host's weight so heavier hosts accept proportionally more load
+	if float64(candidateHost.LoadBound)+1 <= avgLoadPerNode*candidateHost.Weight {
 		return true, nil
 	}
 
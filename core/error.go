@@ -6,4 +6,12 @@ var (
 	ErrHostAlreadyExists = errors.New("host already exists")
 
 	ErrHostNotFound = errors.New("host not found")
+
+	// ErrNoLocalHost is returned by the locality-aware lookups when
+	// LocalityStrict is set and no host in the caller's DC can serve the key.
+	ErrNoLocalHost = errors.New("no host available in caller's datacenter")
+
+	// ErrInsufficientHosts is returned by GetKeyN/GetKeyLeastN when fewer
+	// than n distinct hosts are available to fill the replica set.
+	ErrInsufficientHosts = errors.New("insufficient distinct hosts to satisfy request")
 )
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"sort"
+	"sync"
+)
+
+// RingEventKind identifies the kind of change carried by a RingEvent.
+type RingEventKind int
+
+const (
+	// HostAdded is emitted once per RegisterHost call that adds a new host.
+	HostAdded RingEventKind = iota
+
+	// HostRemoved is emitted once per UnregisterHost call that removes a host.
+	HostRemoved
+
+	// KeyspaceMoved is emitted once per arc of the ring that changed owner as
+	// a result of a RegisterHost/UnregisterHost call.
+	KeyspaceMoved
+)
+
+// RingEvent is emitted on the channels returned by Watch whenever
+// RegisterHost/UnregisterHost changes the ring.
+type RingEvent struct {
+	Kind RingEventKind
+
+	// Host is set when Kind is HostAdded or HostRemoved.
+	Host string
+
+	// FromHost, ToHost, HashRangeStart and HashRangeEnd are set when Kind is
+	// KeyspaceMoved: the arc (HashRangeStart, HashRangeEnd] used to be served
+	// by FromHost and is now served by ToHost.
+	FromHost       string
+	ToHost         string
+	HashRangeStart uint64
+	HashRangeEnd   uint64
+}
+
+// ringEventBufferSize bounds each subscriber's channel so a slow consumer
+// can't stall registration; publish drops the oldest buffered event instead
+// of blocking.
+const ringEventBufferSize = 64
+
+// eventBus fans RingEvents out to every subscriber registered via Watch.
+type eventBus struct {
+	mu   sync.Mutex
+	subs []chan RingEvent
+}
+
+func (b *eventBus) subscribe() <-chan RingEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan RingEvent, ringEventBufferSize)
+	b.subs = append(b.subs, ch)
+	return ch
+}
+
+func (b *eventBus) hasSubscribers() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subs) > 0
+}
+
+// publish sends event to every subscriber. A subscriber whose channel is
+// full has its oldest buffered event dropped to make room, so one slow
+// consumer can't block the caller (RegisterHost/UnregisterHost).
+func (b *eventBus) publish(event RingEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// Watch returns a channel that receives a RingEvent for every
+// RegisterHost/UnregisterHost call that changes the ring. Multiple
+// subscribers are supported; call Watch again to get another channel.
+func (c *Consistent) Watch() <-chan RingEvent {
+	return c.events.subscribe()
+}
+
+// ringOwner returns the host owning the arc ending at hash in the ring
+// described by sortedRing/hostOf, wrapping around like searchKey does.
+func ringOwner(sortedRing []uint64, hostOf map[uint64]string, hash uint64) string {
+	if len(sortedRing) == 0 {
+		return ""
+	}
+
+	idx := sort.Search(len(sortedRing), func(i int) bool {
+		return sortedRing[i] >= hash
+	})
+	if idx >= len(sortedRing) {
+		idx = 0
+	}
+	return hostOf[sortedRing[idx]]
+}
+
+// predecessorOf returns the hash immediately before hash in sortedRing,
+// wrapping around. hash need not itself be present in sortedRing.
+func predecessorOf(sortedRing []uint64, hash uint64) uint64 {
+	idx := sort.Search(len(sortedRing), func(i int) bool {
+		return sortedRing[i] >= hash
+	})
+	predIdx := idx - 1
+	if predIdx < 0 {
+		predIdx = len(sortedRing) - 1
+	}
+	return sortedRing[predIdx]
+}
+
+// snapshotRing copies the ring's current hash slice and replica-to-host map
+// so a later diff can tell who owned which arc before a mutation.
+func (c *Consistent) snapshotRing() ([]uint64, map[uint64]string) {
+	ring := append([]uint64(nil), c.sortedHostsHashSet...)
+
+	hostOf := make(map[uint64]string, len(c.replicaHostMap))
+	for k, v := range c.replicaHostMap {
+		hostOf[k] = v
+	}
+
+	return ring, hostOf
+}
+
+// emitHostAddedKeyspaceEvents emits one KeyspaceMoved event per arc that
+// hostName's new virtual nodes (addedHashes) stole from their previous
+// owner, diffed against the pre-registration ring snapshot.
+func (c *Consistent) emitHostAddedKeyspaceEvents(hostName string, addedHashes []uint64, prevRing []uint64, prevHostOf map[uint64]string) {
+	if len(prevRing) == 0 {
+		return // first host in the ring: no existing arcs to steal
+	}
+
+	for _, h := range addedHashes {
+		oldOwner := ringOwner(prevRing, prevHostOf, h)
+		if oldOwner == "" || oldOwner == hostName {
+			continue
+		}
+
+		c.events.publish(RingEvent{
+			Kind:           KeyspaceMoved,
+			FromHost:       oldOwner,
+			ToHost:         hostName,
+			HashRangeStart: predecessorOf(c.sortedHostsHashSet, h),
+			HashRangeEnd:   h,
+		})
+	}
+}
+
+// emitHostRemovedKeyspaceEvents emits one KeyspaceMoved event per arc that
+// used to belong to hostName's virtual nodes (removedHashes) and now belongs
+// to their successor, diffed against the pre-unregistration ring snapshot.
+func (c *Consistent) emitHostRemovedKeyspaceEvents(hostName string, removedHashes []uint64, prevRing []uint64) {
+	for _, h := range removedHashes {
+		newOwner := ringOwner(c.sortedHostsHashSet, c.replicaHostMap, h)
+		if newOwner == "" || newOwner == hostName {
+			continue
+		}
+
+		c.events.publish(RingEvent{
+			Kind:           KeyspaceMoved,
+			FromHost:       hostName,
+			ToHost:         newOwner,
+			HashRangeStart: predecessorOf(prevRing, h),
+			HashRangeEnd:   h,
+		})
+	}
+}
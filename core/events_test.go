@@ -0,0 +1,81 @@
+package core
+
+import "testing"
+
+func TestConsistent_Watch_HostAddedRemoved(t *testing.T) {
+	c := NewConsistent(10, nil)
+	events := c.Watch()
+
+	_ = c.RegisterHost("127.0.0.1:8000")
+
+	event := <-events
+	if event.Kind != HostAdded || event.Host != "127.0.0.1:8000" {
+		t.Fatalf("expected HostAdded for 127.0.0.1:8000, got %+v", event)
+	}
+
+	_ = c.UnregisterHost("127.0.0.1:8000")
+
+	event = <-events
+	if event.Kind != HostRemoved || event.Host != "127.0.0.1:8000" {
+		t.Fatalf("expected HostRemoved for 127.0.0.1:8000, got %+v", event)
+	}
+}
+
+func TestConsistent_Watch_KeyspaceMoved(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+
+	events := c.Watch()
+
+	_ = c.RegisterHost("host-b")
+
+	sawHostAdded := false
+	sawKeyspaceMoved := false
+	for i := 0; i < 1+10; i++ { // 1 HostAdded + up to replicaNum KeyspaceMoved events
+		select {
+		case event := <-events:
+			switch event.Kind {
+			case HostAdded:
+				sawHostAdded = true
+			case KeyspaceMoved:
+				sawKeyspaceMoved = true
+				if event.ToHost != "host-b" {
+					t.Fatalf("expected keyspace to move to host-b, got %+v", event)
+				}
+			}
+		default:
+		}
+	}
+
+	if !sawHostAdded {
+		t.Fatal("expected a HostAdded event")
+	}
+	if !sawKeyspaceMoved {
+		t.Fatal("expected at least one KeyspaceMoved event")
+	}
+}
+
+func TestConsistent_Watch_MultipleSubscribers(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	a := c.Watch()
+	b := c.Watch()
+
+	_ = c.RegisterHost("host-a")
+
+	if (<-a).Kind != HostAdded {
+		t.Fatal("expected subscriber a to see HostAdded")
+	}
+	if (<-b).Kind != HostAdded {
+		t.Fatal("expected subscriber b to see HostAdded")
+	}
+}
+
+func TestConsistent_Watch_NoSubscribersDoesNotBlock(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+	_ = c.RegisterHost("host-b")
+	_ = c.UnregisterHost("host-a")
+}
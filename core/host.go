@@ -6,4 +6,22 @@ type Host struct {
 
 	// the load bound of the host
 	LoadBound int64
+
+	// the datacenter the host lives in, used by locality-aware policies
+	DC string
+
+	// the rack the host lives in, used by locality-aware policies
+	Rack string
+
+	// the relative capacity of the host; 1.0 is the default uniform weight.
+	// Scales both the number of virtual nodes the host gets on the ring and
+	// its load bound cap.
+	Weight float64
+}
+
+// HostMeta carries the optional topology labels for a host, used with
+// RegisterHostWithMeta.
+type HostMeta struct {
+	DC   string
+	Rack string
 }
@@ -0,0 +1,153 @@
+package core
+
+import "math"
+
+// LocalityPolicy controls how GetKeyFrom and GetKeyLeastFrom pick a host
+// relative to the caller's datacenter.
+type LocalityPolicy int
+
+const (
+	// LocalityNone ignores locality; GetKeyFrom/GetKeyLeastFrom behave
+	// exactly like GetKey/GetKeyLeast. This is the default.
+	LocalityNone LocalityPolicy = iota
+
+	// LocalityPreferLocal walks the ring from the key's primary position,
+	// skipping hosts whose DC doesn't match the caller's, and falls back to
+	// any host if the caller's DC has none available.
+	LocalityPreferLocal
+
+	// LocalityStrict behaves like LocalityPreferLocal but returns
+	// ErrNoLocalHost instead of falling back to a remote DC.
+	LocalityStrict
+)
+
+// SetLocalityPolicy sets the policy used by GetKeyFrom/GetKeyLeastFrom.
+func (c *Consistent) SetLocalityPolicy(policy LocalityPolicy) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.localityPolicy = policy
+}
+
+// RegisterHostWithMeta registers hostName like RegisterHost, additionally
+// recording its DC/Rack labels for use by locality-aware policies.
+func (c *Consistent) RegisterHostWithMeta(hostName string, meta HostMeta) error {
+	return c.registerHost(hostName, meta, 1)
+}
+
+// GetKeyFrom returns the host that should serve key for a caller in
+// callerDC, honoring c's LocalityPolicy. With LocalityNone, or when callerDC
+// is empty, it behaves exactly like GetKey.
+func (c *Consistent) GetKeyFrom(key string, callerDC string) (string, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if len(c.sortedHostsHashSet) == 0 {
+		return "", ErrHostNotFound
+	}
+
+	if c.localityPolicy == LocalityNone || callerDC == "" {
+		if c.strategy != nil {
+			return c.strategy.Select(c, c.hashFunc(key))
+		}
+		if c.backend == backendMaglev {
+			return c.getKeyMaglev(key)
+		}
+		hashedKey := c.hashFunc(key)
+		idx := c.searchKey(hashedKey)
+		return c.replicaHostMap[c.sortedHostsHashSet[idx]], nil
+	}
+
+	hashedKey := c.hashFunc(key)
+	idx := c.searchKey(hashedKey)
+
+	fallback := ""
+	for i := 0; i < len(c.sortedHostsHashSet); i++ {
+		host := c.replicaHostMap[c.sortedHostsHashSet[(idx+i)%len(c.sortedHostsHashSet)]]
+		if fallback == "" {
+			fallback = host
+		}
+		if c.hostMap[host].DC == callerDC {
+			return host, nil
+		}
+	}
+
+	if c.localityPolicy == LocalityStrict {
+		return "", ErrNoLocalHost
+	}
+	return fallback, nil
+}
+
+// GetKeyLeastFrom is the locality-aware counterpart to GetKeyLeast. It picks
+// the least loaded host in callerDC that can serve key, computing the load
+// bound against that DC's own average load rather than the cluster-wide
+// average. Policy handling mirrors GetKeyFrom.
+func (c *Consistent) GetKeyLeastFrom(key string, callerDC string) (string, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if len(c.replicaHostMap) == 0 {
+		return "", ErrHostNotFound
+	}
+
+	if c.localityPolicy == LocalityNone || callerDC == "" {
+		return c.getKeyLeastLocked(key)
+	}
+
+	hashedKey := c.hashFunc(key)
+	idx := c.searchKey(hashedKey)
+
+	i := idx
+	for attempts := 0; attempts < len(c.replicaHostMap); attempts++ {
+		host := c.replicaHostMap[c.sortedHostsHashSet[i]]
+		if c.hostMap[host].DC == callerDC {
+			loadChecked, err := c.checkLoadCapacityInDC(host, callerDC)
+			if err == nil && loadChecked {
+				return host, nil
+			}
+		}
+		i++
+		if i >= len(c.replicaHostMap) {
+			i = 0
+		}
+	}
+
+	if c.localityPolicy == LocalityStrict {
+		return "", ErrNoLocalHost
+	}
+	return c.getKeyLeastLocked(key)
+}
+
+// checkLoadCapacityInDC is the per-DC counterpart to checkLoadCapacity: host
+// is checked against the average load of hosts in dc instead of the
+// cluster-wide average.
+func (c *Consistent) checkLoadCapacityInDC(host string, dc string) (bool, error) {
+	candidateHost, ok := c.hostMap[host]
+	if !ok {
+		return false, ErrHostNotFound
+	}
+
+	var dcLoad int64
+	var dcHosts int64
+	for _, h := range c.hostMap {
+		if h.DC == dc {
+			dcLoad += h.LoadBound
+			dcHosts++
+		}
+	}
+	if dcHosts == 0 {
+		return false, ErrNoLocalHost
+	}
+
+	var avgLoadPerNode float64
+	avgLoadPerNode = float64((dcLoad + 1) / dcHosts)
+	if avgLoadPerNode == 0 {
+		avgLoadPerNode = 1
+	}
+	avgLoadPerNode = math.Ceil(avgLoadPerNode * (1 + loadBoundFactor))
+
+	if float64(candidateHost.LoadBound)+1 <= avgLoadPerNode*candidateHost.Weight {
+		return true, nil
+	}
+	return false, nil
+}
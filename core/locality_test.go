@@ -0,0 +1,68 @@
+package core
+
+import "testing"
+
+func TestConsistent_GetKeyFrom(t *testing.T) {
+	c := NewConsistent(10, nil)
+	c.SetLocalityPolicy(LocalityPreferLocal)
+
+	_ = c.RegisterHostWithMeta("dc1-host", HostMeta{DC: "dc1"})
+	_ = c.RegisterHostWithMeta("dc2-host", HostMeta{DC: "dc2"})
+
+	host, err := c.GetKeyFrom("1234", "dc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "dc1-host" {
+		t.Fatalf("expected dc1-host, got %s", host)
+	}
+
+	host, err = c.GetKeyFrom("1234", "dc2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "dc2-host" {
+		t.Fatalf("expected dc2-host, got %s", host)
+	}
+
+	// no dc3 host exists, LocalityPreferLocal should fall back
+	host, err = c.GetKeyFrom("1234", "dc3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "dc1-host" && host != "dc2-host" {
+		t.Fatalf("unexpected fallback host: %s", host)
+	}
+}
+
+func TestConsistent_GetKeyFrom_Strict(t *testing.T) {
+	c := NewConsistent(10, nil)
+	c.SetLocalityPolicy(LocalityStrict)
+
+	_ = c.RegisterHostWithMeta("dc1-host", HostMeta{DC: "dc1"})
+
+	_, err := c.GetKeyFrom("1234", "dc2")
+	if err != ErrNoLocalHost {
+		t.Fatalf("expected ErrNoLocalHost, got %v", err)
+	}
+}
+
+func TestConsistent_GetKeyLeastFrom(t *testing.T) {
+	c := NewConsistent(10, nil)
+	c.SetLocalityPolicy(LocalityPreferLocal)
+
+	_ = c.RegisterHostWithMeta("dc1-host-a", HostMeta{DC: "dc1"})
+	_ = c.RegisterHostWithMeta("dc1-host-b", HostMeta{DC: "dc1"})
+	_ = c.RegisterHostWithMeta("dc2-host", HostMeta{DC: "dc2"})
+
+	for i := 0; i < 50; i++ {
+		host, err := c.GetKeyLeastFrom("key", "dc1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.hostMap[host].DC != "dc1" {
+			t.Fatalf("expected a dc1 host, got %s", host)
+		}
+		c.Inc(host)
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import "sort"
+
+// backend selects the underlying lookup implementation used by Consistent.
+type backend int
+
+const (
+	// backendRing is the classic sorted-hash-ring implementation: O(log N)
+	// lookups via binary search over sortedHostsHashSet.
+	backendRing backend = iota
+
+	// backendMaglev is a Maglev-style precomputed lookup table: O(1) lookups
+	// at the cost of rebuilding the table on every host change.
+	backendMaglev
+)
+
+// defaultMaglevTableSize is used when NewConsistentMaglev is given a
+// non-positive table size. It's prime and comfortably larger than any
+// realistic host count.
+var defaultMaglevTableSize = 65537
+
+// NewConsistentMaglev creates a Consistent backed by a Maglev-style lookup
+// table instead of the default sorted hash ring. RegisterHost, UnregisterHost,
+// GetKey and GetKeyLeast keep their usual semantics, but GetKey answers in
+// O(1) via a precomputed table of size tableSize rather than a binary search
+// over the ring.
+//
+// tableSize should be a prime number much larger than the expected number of
+// hosts (e.g. 65537) so that every host's permutation of the table can be
+// walked without collisions degrading the distribution; a non-prime size is
+// rounded up to the next prime, since the permutation only covers all of
+// [0,tableSize) when gcd(skip, tableSize) == 1, which composite sizes can't
+// guarantee.
+//
+// ref: https://storage.googleapis.com/pub-tools-public-publication-data/pdf/44824.pdf
+func NewConsistentMaglev(tableSize int, hashFunc func(key string) uint64) *Consistent {
+	if tableSize <= 0 {
+		tableSize = defaultMaglevTableSize
+	}
+	tableSize = nextPrime(tableSize)
+
+	c := NewConsistent(1, hashFunc)
+	c.backend = backendMaglev
+	c.maglevTableSize = tableSize
+	c.lookupTable = make([]string, tableSize)
+
+	return c
+}
+
+// isPrime reports whether n (n >= 2) is prime.
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	if n%2 == 0 {
+		return n == 2
+	}
+	for d := 3; d*d <= n; d += 2 {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// nextPrime returns the smallest prime >= n (n < 2 rounds up to 2).
+func nextPrime(n int) int {
+	if n < 2 {
+		n = 2
+	}
+	for !isPrime(n) {
+		n++
+	}
+	return n
+}
+
+// rebuildMaglevLookup recomputes the Maglev lookup table from the current
+// host set. It must be called with c's write lock held, after RegisterHost or
+// UnregisterHost mutates c.hostMap.
+func (c *Consistent) rebuildMaglevLookup() {
+	m := c.maglevTableSize
+
+	hostNames := make([]string, 0, len(c.hostMap))
+	for name := range c.hostMap {
+		hostNames = append(hostNames, name)
+	}
+	// sort for a deterministic table build order across rebuilds
+	sort.Strings(hostNames)
+
+	table := make([]string, m)
+	if len(hostNames) == 0 {
+		c.lookupTable = table
+		return
+	}
+
+	filled := make([]bool, m)
+	permutation := make([][]int, len(hostNames))
+	next := make([]int, len(hostNames))
+
+	for i, name := range hostNames {
+		h1 := c.hashFunc(name + "#offset")
+		h2 := c.hashFunc(name + "#skip")
+
+		offset := int(h1 % uint64(m))
+		skip := int(h2%uint64(m-1)) + 1
+
+		perm := make([]int, m)
+		for j := 0; j < m; j++ {
+			perm[j] = (offset + j*skip) % m
+		}
+		permutation[i] = perm
+	}
+
+	// m is prime (NewConsistentMaglev rounds the table size up to one), so
+	// every skip is coprime with m and each host's permutation visits every
+	// slot; the inner probe therefore always reaches an empty slot while
+	// filledCount < m. next[i] is taken mod m as a guard on the index.
+	filledCount := 0
+	for filledCount < m {
+		for i := range hostNames {
+			if filledCount == m {
+				break
+			}
+
+			slot := permutation[i][next[i]%m]
+			for filled[slot] {
+				next[i]++
+				slot = permutation[i][next[i]%m]
+			}
+			table[slot] = hostNames[i]
+			filled[slot] = true
+			next[i]++
+			filledCount++
+		}
+	}
+
+	c.lookupTable = table
+}
+
+// getKeyMaglev answers GetKey in O(1) via the precomputed lookup table.
+func (c *Consistent) getKeyMaglev(key string) (string, error) {
+	if len(c.lookupTable) == 0 {
+		return "", ErrHostNotFound
+	}
+
+	hashedKey := c.hashFunc(key)
+	idx := int(hashedKey % uint64(len(c.lookupTable)))
+
+	host := c.lookupTable[idx]
+	if host == "" {
+		return "", ErrHostNotFound
+	}
+	return host, nil
+}
+
+// getKeyLeastMaglev walks the Maglev lookup table starting at the key's slot,
+// across subsequent distinct hosts, until it finds one within the load bound.
+func (c *Consistent) getKeyLeastMaglev(key string) (string, error) {
+	if len(c.lookupTable) == 0 {
+		return "", ErrHostNotFound
+	}
+
+	hashedKey := c.hashFunc(key)
+	start := int(hashedKey % uint64(len(c.lookupTable)))
+
+	seen := make(map[string]bool, len(c.hostMap))
+	for i := 0; i < len(c.lookupTable); i++ {
+		host := c.lookupTable[(start+i)%len(c.lookupTable)]
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+
+		loadChecked, err := c.checkLoadCapacity(host)
+		if err != nil {
+			return "", err
+		}
+		if loadChecked {
+			return host, nil
+		}
+	}
+
+	return "", ErrHostNotFound
+}
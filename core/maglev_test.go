@@ -0,0 +1,145 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConsistentMaglev_CompositeTableSizeRoundsToPrime(t *testing.T) {
+	c := NewConsistentMaglev(1024, nil) // 1024 = 2^10, deliberately non-prime
+	if !isPrime(c.maglevTableSize) {
+		t.Fatalf("expected maglevTableSize to be rounded up to a prime, got %d", c.maglevTableSize)
+	}
+
+	// used to panic with "index out of range" once a host's reachable slots
+	// filled before the table did
+	for i := 0; i < 8; i++ {
+		_ = c.RegisterHost(fmt.Sprintf("host-%d", i))
+	}
+}
+
+func TestConsistentMaglev_RegisterUnregisterHost(t *testing.T) {
+	c := NewConsistentMaglev(1031, nil)
+
+	_ = c.RegisterHost("127.0.0.1:8000")
+	if len(c.Hosts()) != 1 {
+		t.Errorf("Expected 1 node in ring, got %d", len(c.Hosts()))
+	}
+	if len(c.lookupTable) != 1031 {
+		t.Errorf("Expected lookup table of size 1031, got %d", len(c.lookupTable))
+	}
+
+	_ = c.RegisterHost("127.0.0.1:9999")
+	if len(c.Hosts()) != 2 {
+		t.Errorf("Expected 2 node in ring, got %d", len(c.Hosts()))
+	}
+
+	err := c.UnregisterHost("127.0.0.1:8000")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if len(c.Hosts()) != 1 {
+		t.Errorf("Expected 1 node in ring, got %d", len(c.Hosts()))
+	}
+}
+
+func TestConsistentMaglev_GetKey(t *testing.T) {
+	c := NewConsistentMaglev(1031, nil)
+
+	_, err := c.GetKey("1234")
+	if err != ErrHostNotFound {
+		t.Fatalf("expected ErrHostNotFound on empty table, got %v", err)
+	}
+
+	_ = c.RegisterHost("127.0.0.1:8000")
+	_ = c.RegisterHost("192.168.0.1:8999")
+
+	for i := 0; i < 1000; i++ {
+		host, err := c.GetKey(fmt.Sprintf("key-%d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if host != "127.0.0.1:8000" && host != "192.168.0.1:8999" {
+			t.Fatalf("unexpected host returned: %s", host)
+		}
+	}
+}
+
+func TestConsistentMaglev_GetKeyLeast(t *testing.T) {
+	c := NewConsistentMaglev(1031, nil)
+
+	_ = c.RegisterHost("127.0.0.1:8000")
+	_ = c.RegisterHost("92.0.0.1:8000")
+
+	for i := 0; i < 100; i++ {
+		host, err := c.GetKeyLeast("1234")
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Inc(host)
+	}
+
+	for k, v := range c.GetLoads() {
+		if v > c.MaxLoad() {
+			t.Fatalf("host %s is overloaded. %d > %d\n", k, v, c.MaxLoad())
+		}
+	}
+}
+
+func TestConsistentMaglev_MinimalDisruption(t *testing.T) {
+	c := NewConsistentMaglev(1031, nil)
+
+	_ = c.RegisterHost("host-a")
+	_ = c.RegisterHost("host-b")
+	_ = c.RegisterHost("host-c")
+
+	before := make(map[string]string, 200)
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		host, err := c.GetKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before[key] = host
+	}
+
+	_ = c.RegisterHost("host-d")
+
+	moved := 0
+	for key, host := range before {
+		after, err := c.GetKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if after != host {
+			moved++
+		}
+	}
+
+	// Maglev aims for close to 1/N of keys moving when adding the Nth host;
+	// just assert it's nowhere near a full reshuffle.
+	if moved > len(before)/2 {
+		t.Fatalf("too much disruption: %d/%d keys moved", moved, len(before))
+	}
+}
+
+// TestConsistentMaglev_GetKeyConcurrentWithRegister exercises GetKey racing
+// against RegisterHost under `go test -race`: both touch c.lookupTable, and
+// GetKey must take c's read lock to be safe.
+func TestConsistentMaglev_GetKeyConcurrentWithRegister(t *testing.T) {
+	c := NewConsistentMaglev(1031, nil)
+	_ = c.RegisterHost("host-0")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			_, _ = c.GetKey(fmt.Sprintf("key-%d", i))
+		}
+	}()
+
+	for i := 1; i <= 5; i++ {
+		_ = c.RegisterHost(fmt.Sprintf("host-%d", i))
+	}
+	<-done
+}
@@ -0,0 +1,120 @@
+package core
+
+// GetKeyN returns the next n distinct physical hosts encountered while
+// walking the ring from key's hash position, skipping virtual-node
+// duplicates that belong to the same host. This mirrors how token-ring
+// stores (e.g. Cassandra) pick a replica set for a key, letting callers fan
+// out quorum reads or hinted handoff across the result.
+//
+// It returns ErrInsufficientHosts if fewer than n distinct hosts exist.
+func (c *Consistent) GetKeyN(key string, n int) ([]string, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.backend == backendMaglev {
+		return c.getKeyNMaglev(key, n, nil)
+	}
+	return c.getKeyNRing(key, n, nil)
+}
+
+// GetKeyLeastN is the bounded-load counterpart to GetKeyN: a candidate host
+// only joins the replica set if checkLoadCapacity allows it, and the walk
+// keeps going past hosts that are over their load bound to fill the set.
+//
+// It returns ErrInsufficientHosts if fewer than n distinct hosts end up
+// satisfying the load bound.
+func (c *Consistent) GetKeyLeastN(key string, n int) ([]string, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.backend == backendMaglev {
+		return c.getKeyNMaglev(key, n, c.checkLoadCapacity)
+	}
+	return c.getKeyNRing(key, n, c.checkLoadCapacity)
+}
+
+// getKeyNRing walks sortedHostsHashSet from key's primary position and
+// collects up to n distinct hosts satisfying filter (if non-nil). Callers
+// must hold at least c's read lock.
+func (c *Consistent) getKeyNRing(key string, n int, filter func(string) (bool, error)) ([]string, error) {
+	if len(c.sortedHostsHashSet) == 0 || len(c.hostMap) == 0 {
+		return nil, ErrInsufficientHosts
+	}
+	if n > len(c.hostMap) {
+		return nil, ErrInsufficientHosts
+	}
+
+	hashedKey := c.hashFunc(key)
+	idx := c.searchKey(hashedKey)
+
+	seen := make(map[string]bool, n)
+	hosts := make([]string, 0, n)
+
+	for i := 0; i < len(c.sortedHostsHashSet) && len(hosts) < n; i++ {
+		host := c.replicaHostMap[c.sortedHostsHashSet[(idx+i)%len(c.sortedHostsHashSet)]]
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+
+		if filter != nil {
+			ok, err := filter(host)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	if len(hosts) < n {
+		return nil, ErrInsufficientHosts
+	}
+	return hosts, nil
+}
+
+// getKeyNMaglev is getKeyNRing's counterpart for the Maglev backend: it walks
+// the lookup table instead of the ring. Callers must hold at least c's read
+// lock.
+func (c *Consistent) getKeyNMaglev(key string, n int, filter func(string) (bool, error)) ([]string, error) {
+	if len(c.lookupTable) == 0 || len(c.hostMap) == 0 {
+		return nil, ErrInsufficientHosts
+	}
+	if n > len(c.hostMap) {
+		return nil, ErrInsufficientHosts
+	}
+
+	hashedKey := c.hashFunc(key)
+	start := int(hashedKey % uint64(len(c.lookupTable)))
+
+	seen := make(map[string]bool, n)
+	hosts := make([]string, 0, n)
+
+	for i := 0; i < len(c.lookupTable) && len(hosts) < n; i++ {
+		host := c.lookupTable[(start+i)%len(c.lookupTable)]
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+
+		if filter != nil {
+			ok, err := filter(host)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	if len(hosts) < n {
+		return nil, ErrInsufficientHosts
+	}
+	return hosts, nil
+}
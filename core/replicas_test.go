@@ -0,0 +1,43 @@
+package core
+
+import "testing"
+
+func TestConsistent_GetKeyN(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+	_ = c.RegisterHost("host-b")
+	_ = c.RegisterHost("host-c")
+
+	hosts, err := c.GetKeyN("1234", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(hosts))
+	}
+	if hosts[0] == hosts[1] {
+		t.Fatalf("expected distinct hosts, got %v", hosts)
+	}
+
+	_, err = c.GetKeyN("1234", 4)
+	if err != ErrInsufficientHosts {
+		t.Fatalf("expected ErrInsufficientHosts, got %v", err)
+	}
+}
+
+func TestConsistent_GetKeyLeastN(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+	_ = c.RegisterHost("host-b")
+	_ = c.RegisterHost("host-c")
+
+	hosts, err := c.GetKeyLeastN("1234", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 3 {
+		t.Fatalf("expected 3 hosts, got %d", len(hosts))
+	}
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// SelectionStrategy picks a host for a key, given the ring and the key's
+// hashed value. Implementations may ignore hashedKey entirely (e.g.
+// RoundRobinStrategy). Select is called with ring's read lock already held,
+// so implementations must not lock ring themselves.
+type SelectionStrategy interface {
+	Select(ring *Consistent, hashedKey uint64) (string, error)
+}
+
+// NewConsistentWithStrategy creates a Consistent whose GetKey/GetKeyLeast are
+// answered by strategy instead of the default ring/bounded-load lookup.
+func NewConsistentWithStrategy(replicaNum int, hashFunc func(key string) uint64, strategy SelectionStrategy) *Consistent {
+	c := NewConsistent(replicaNum, hashFunc)
+	c.strategy = strategy
+	return c
+}
+
+// GetKeyWithStrategy selects a host for key using strategy instead of c's
+// default ring lookup. Built-in strategies are RoundRobinStrategy,
+// LeastConnectionsStrategy and P2CStrategy; set c's strategy permanently via
+// SetSelectionStrategy, or call this directly to pick one per call.
+func (c *Consistent) GetKeyWithStrategy(key string, strategy SelectionStrategy) (string, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	return strategy.Select(c, c.hashFunc(key))
+}
+
+// SetSelectionStrategy makes GetKey/GetKeyLeast delegate to strategy from
+// now on. Pass nil to restore the default ring/bounded-load behavior.
+func (c *Consistent) SetSelectionStrategy(strategy SelectionStrategy) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.strategy = strategy
+}
+
+// RoundRobinStrategy ignores hashedKey and cycles through registered hosts
+// in order, useful as a locality/load-agnostic fallback.
+type RoundRobinStrategy struct {
+	counter uint64
+}
+
+func (s *RoundRobinStrategy) Select(ring *Consistent, hashedKey uint64) (string, error) {
+	hosts := make([]string, 0, len(ring.hostMap))
+	for name := range ring.hostMap {
+		hosts = append(hosts, name)
+	}
+	if len(hosts) == 0 {
+		return "", ErrHostNotFound
+	}
+	sort.Strings(hosts) // stable order so the round-robin cycle is well-defined
+
+	idx := atomic.AddUint64(&s.counter, 1) - 1
+	return hosts[idx%uint64(len(hosts))], nil
+}
+
+// LeastConnectionsStrategy ignores the ring position and returns the
+// globally least-loaded host, ties broken by host name.
+type LeastConnectionsStrategy struct{}
+
+func (s LeastConnectionsStrategy) Select(ring *Consistent, hashedKey uint64) (string, error) {
+	if len(ring.hostMap) == 0 {
+		return "", ErrHostNotFound
+	}
+
+	names := make([]string, 0, len(ring.hostMap))
+	for name := range ring.hostMap {
+		names = append(names, name)
+	}
+	sort.Strings(names) // deterministic tie-break
+
+	best := names[0]
+	for _, name := range names[1:] {
+		if ring.hostMap[name].LoadBound < ring.hostMap[best].LoadBound {
+			best = name
+		}
+	}
+	return best, nil
+}
+
+// P2CStrategy implements power-of-two-choices: it picks the first two
+// distinct hosts encountered walking the ring from hashedKey's position and
+// returns whichever currently has the lower load, which reduces tail latency
+// versus bounded-load alone under skewed workloads.
+type P2CStrategy struct{}
+
+func (s P2CStrategy) Select(ring *Consistent, hashedKey uint64) (string, error) {
+	if len(ring.sortedHostsHashSet) == 0 {
+		return "", ErrHostNotFound
+	}
+
+	idx := ring.searchKey(hashedKey)
+
+	first, second := "", ""
+	for i := 0; i < len(ring.sortedHostsHashSet); i++ {
+		host := ring.replicaHostMap[ring.sortedHostsHashSet[(idx+i)%len(ring.sortedHostsHashSet)]]
+		if first == "" {
+			first = host
+			continue
+		}
+		if host != first {
+			second = host
+			break
+		}
+	}
+
+	if first == "" {
+		return "", ErrHostNotFound
+	}
+	if second == "" {
+		return first, nil
+	}
+
+	if ring.hostMap[second].LoadBound < ring.hostMap[first].LoadBound {
+		return second, nil
+	}
+	return first, nil
+}
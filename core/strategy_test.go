@@ -0,0 +1,74 @@
+package core
+
+import "testing"
+
+func TestConsistent_GetKeyWithStrategy_RoundRobin(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+	_ = c.RegisterHost("host-b")
+
+	rr := &RoundRobinStrategy{}
+
+	seen := make(map[string]int)
+	for i := 0; i < 4; i++ {
+		host, err := c.GetKeyWithStrategy("ignored", rr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[host]++
+	}
+
+	if seen["host-a"] != 2 || seen["host-b"] != 2 {
+		t.Fatalf("expected an even round-robin split, got %v", seen)
+	}
+}
+
+func TestConsistent_GetKeyWithStrategy_LeastConnections(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+	_ = c.RegisterHost("host-b")
+	c.UpdateLoad("host-a", 5)
+
+	host, err := c.GetKeyWithStrategy("key", LeastConnectionsStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "host-b" {
+		t.Fatalf("expected host-b (less loaded), got %s", host)
+	}
+}
+
+func TestConsistent_GetKeyWithStrategy_P2C(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+	_ = c.RegisterHost("host-b")
+
+	host, err := c.GetKeyWithStrategy("key", P2CStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "host-a" && host != "host-b" {
+		t.Fatalf("unexpected host: %s", host)
+	}
+}
+
+func TestConsistent_SetSelectionStrategy(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+	_ = c.RegisterHost("host-b")
+	c.UpdateLoad("host-a", 5)
+
+	c.SetSelectionStrategy(LeastConnectionsStrategy{})
+
+	host, err := c.GetKey("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "host-b" {
+		t.Fatalf("expected GetKey to delegate to the strategy and return host-b, got %s", host)
+	}
+}
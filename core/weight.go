@@ -0,0 +1,106 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// RegisterHostWithWeight registers hostName like RegisterHost, but gives it
+// round(weight*replicaNum) virtual nodes instead of the uniform replicaNum,
+// and scales its load bound cap proportionally in checkLoadCapacity. Use
+// this for heterogeneous backends (larger cache nodes, newer hardware).
+func (c *Consistent) RegisterHostWithWeight(hostName string, weight float64) error {
+	return c.registerHost(hostName, HostMeta{}, weight)
+}
+
+// UpdateWeight changes hostName's weight, incrementally inserting or removing
+// the delta virtual nodes rather than rebuilding sortedHostsHashSet from
+// scratch.
+func (c *Consistent) UpdateWeight(hostName string, weight float64) error {
+	c.Lock()
+	defer c.Unlock()
+
+	host, ok := c.hostMap[hostName]
+	if !ok {
+		return ErrHostNotFound
+	}
+
+	if weight <= 0 {
+		weight = 1
+	}
+
+	oldReplicas := hostReplicaCount(host.Weight, c.replicaNum)
+	newReplicas := hostReplicaCount(weight, c.replicaNum)
+	host.Weight = weight
+
+	switch {
+	case newReplicas > oldReplicas:
+		for i := oldReplicas; i < newReplicas; i++ {
+			hashedIdx := c.hashFunc(fmt.Sprintf(hostReplicaFormat, hostName, i))
+			c.replicaHostMap[hashedIdx] = hostName
+			c.insertHashIndex(hashedIdx)
+		}
+	case newReplicas < oldReplicas:
+		for i := newReplicas; i < oldReplicas; i++ {
+			hashedIdx := c.hashFunc(fmt.Sprintf(hostReplicaFormat, hostName, i))
+			delete(c.replicaHostMap, hashedIdx)
+			c.delHashIndex(hashedIdx)
+		}
+	}
+
+	if c.backend == backendMaglev {
+		c.rebuildMaglevLookup()
+	}
+
+	return nil
+}
+
+// MaxLoadForHost returns the maximum load hostName can carry before
+// checkLoadCapacity starts skipping it, i.e. MaxLoad scaled by the host's
+// Weight.
+func (c *Consistent) MaxLoadForHost(hostName string) (int64, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	host, ok := c.hostMap[hostName]
+	if !ok {
+		return 0, ErrHostNotFound
+	}
+
+	totalLoad := c.totalLoad
+	if totalLoad == 0 {
+		totalLoad = 1
+	}
+
+	avgLoadPerNode := float64(totalLoad / int64(len(c.hostMap)))
+	if avgLoadPerNode == 0 {
+		avgLoadPerNode = 1
+	}
+	avgLoadPerNode = math.Ceil(avgLoadPerNode*(1+loadBoundFactor)) * host.Weight
+
+	return int64(avgLoadPerNode), nil
+}
+
+// hostReplicaCount is the number of virtual nodes a host of the given weight
+// gets on the ring, out of replicaNum for a weight of 1.0. Always at least 1
+// so a registered host is never invisible on the ring.
+func hostReplicaCount(weight float64, replicaNum int) int {
+	n := int(math.Round(weight * float64(replicaNum)))
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// insertHashIndex inserts val into the sorted hash ring in place, keeping it
+// sorted without re-sorting the whole slice.
+func (c *Consistent) insertHashIndex(val uint64) {
+	idx := sort.Search(len(c.sortedHostsHashSet), func(i int) bool {
+		return c.sortedHostsHashSet[i] >= val
+	})
+
+	c.sortedHostsHashSet = append(c.sortedHostsHashSet, 0)
+	copy(c.sortedHostsHashSet[idx+1:], c.sortedHostsHashSet[idx:])
+	c.sortedHostsHashSet[idx] = val
+}
@@ -0,0 +1,65 @@
+package core
+
+import "testing"
+
+func TestConsistent_RegisterHostWithWeight(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHostWithWeight("host-a", 1)
+	_ = c.RegisterHostWithWeight("host-b", 2)
+
+	if len(c.sortedHostsHashSet) != 10+20 {
+		t.Fatalf("expected 30 virtual nodes, got %d", len(c.sortedHostsHashSet))
+	}
+}
+
+func TestConsistent_UpdateWeight(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHost("host-a")
+	if len(c.sortedHostsHashSet) != 10 {
+		t.Fatalf("expected 10 virtual nodes, got %d", len(c.sortedHostsHashSet))
+	}
+
+	err := c.UpdateWeight("host-a", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.sortedHostsHashSet) != 30 {
+		t.Fatalf("expected 30 virtual nodes after weight increase, got %d", len(c.sortedHostsHashSet))
+	}
+
+	err = c.UpdateWeight("host-a", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.sortedHostsHashSet) != 10 {
+		t.Fatalf("expected 10 virtual nodes after weight decrease, got %d", len(c.sortedHostsHashSet))
+	}
+
+	err = c.UpdateWeight("missing-host", 2)
+	if err != ErrHostNotFound {
+		t.Fatalf("expected ErrHostNotFound, got %v", err)
+	}
+}
+
+func TestConsistent_MaxLoadForHost(t *testing.T) {
+	c := NewConsistent(10, nil)
+
+	_ = c.RegisterHostWithWeight("host-a", 1)
+	_ = c.RegisterHostWithWeight("host-b", 2)
+
+	c.UpdateLoad("host-a", 10)
+
+	maxA, err := c.MaxLoadForHost("host-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxB, err := c.MaxLoadForHost("host-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maxB <= maxA {
+		t.Fatalf("expected host-b (weight 2) to have a higher cap than host-a, got %d <= %d", maxB, maxA)
+	}
+}
@@ -24,6 +24,7 @@ func startServer(port string) {
 	http.HandleFunc("/unregister", unregisterHost)
 	http.HandleFunc("/key", getKey)
 	http.HandleFunc("/key_least", getKeyLeast)
+	http.HandleFunc("/key_strategy", getKeyWithStrategy)
 
 	fmt.Printf("start proxy server: %s\n", port)
 
@@ -84,3 +85,16 @@ func getKeyLeast(w http.ResponseWriter, r *http.Request) {
 
 	_, _ = fmt.Fprintf(w, fmt.Sprintf("key: %s, val: %s", r.Form["key"][0], val))
 }
+
+func getKeyWithStrategy(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	val, err := p.GetKeyWithStrategy(r.Form["key"][0], r.Form["strategy"][0])
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprintf(w, err.Error())
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, fmt.Sprintf("key: %s, val: %s", r.Form["key"][0], val))
+}
@@ -10,16 +10,40 @@ import (
 
 type Proxy struct {
 	consistent *core.Consistent
+
+	// roundRobin is kept across requests so the round-robin cycle actually
+	// advances, unlike the stateless strategies
+	roundRobin core.SelectionStrategy
 }
 
 // NewProxy creates a new Proxy
 func NewProxy(consistent *core.Consistent) *Proxy {
 	proxy := &Proxy{
 		consistent: consistent,
+		roundRobin: &core.RoundRobinStrategy{},
 	}
+
+	go proxy.watchRing()
+
 	return proxy
 }
 
+// watchRing subscribes to the ring's change event bus so the proxy notices
+// ownership changes as soon as they happen, instead of discovering a host is
+// gone on the next failed http.Get.
+func (p *Proxy) watchRing() {
+	for event := range p.consistent.Watch() {
+		switch event.Kind {
+		case core.HostAdded:
+			fmt.Printf("ring: host added: %s\n", event.Host)
+		case core.HostRemoved:
+			fmt.Printf("ring: host removed: %s\n", event.Host)
+		case core.KeyspaceMoved:
+			fmt.Printf("ring: keyspace (%d, %d] moved from %s to %s\n", event.HashRangeStart, event.HashRangeEnd, event.FromHost, event.ToHost)
+		}
+	}
+}
+
 func (p *Proxy) GetKey(key string) (string, error) {
 
 	host, err := p.consistent.GetKey(key)
@@ -66,6 +90,49 @@ func (p *Proxy) GetKeyLeast(key string) (string, error) {
 	return string(body), nil
 }
 
+// GetKeyWithStrategy is like GetKey but picks the host using the named
+// selection strategy ("round_robin", "least_connections" or "p2c") instead of
+// the ring's default lookup.
+func (p *Proxy) GetKeyWithStrategy(key string, strategy string) (string, error) {
+
+	sel, err := p.resolveStrategy(strategy)
+	if err != nil {
+		return "", err
+	}
+
+	host, err := p.consistent.GetKeyWithStrategy(key, sel)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.Get(fmt.Sprintf("http://%s?key=%s", host, key))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	fmt.Printf("Response from host %s: %s\n", host, string(body))
+
+	return string(body), nil
+}
+
+// resolveStrategy maps a strategy name from the HTTP layer to a
+// core.SelectionStrategy.
+func (p *Proxy) resolveStrategy(strategy string) (core.SelectionStrategy, error) {
+	switch strategy {
+	case "round_robin":
+		return p.roundRobin, nil
+	case "least_connections":
+		return core.LeastConnectionsStrategy{}, nil
+	case "p2c":
+		return core.P2CStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown selection strategy: %s", strategy)
+	}
+}
+
 func (p *Proxy) RegisterHost(host string) error {
 
 	err := p.consistent.RegisterHost(host)